@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,18 +11,23 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sync"
 	"time"
 
 	"github.com/spf13/pflag"
 
-	"github.com/go-enry/go-license-detector/v4/licensedb"
 	"gopkg.in/yaml.v2"
+
+	"github.com/JoakimSoderberg/go-license-finder/pkg/classifier"
+	"github.com/JoakimSoderberg/go-license-finder/pkg/licenseurl"
+	"github.com/JoakimSoderberg/go-license-finder/pkg/scanner"
+	"github.com/JoakimSoderberg/go-license-finder/pkg/spdx"
 )
 
 // TODO: Add support for google/go-licenses also
 // TODO: Move all but cli parts into separate package
 // TODO: Add tests
-// TODO: Get rid of dependency to go-license-detector
 // TODO: Add support for separating each part of the scan
 //       for example, finding the potential license files
 
@@ -64,6 +71,9 @@ type Dependency struct {
 	Dir     string  `json:"Dir"`
 	GoMod   string  `json:"GoMod"`
 	License License `json:"License"`
+	// LicenseURL is an optional, user-supplied URL for the dependency's
+	// license, consulted when no license file can be found in Dir.
+	LicenseURL string `json:"LicenseURL"`
 }
 
 var verbose bool
@@ -72,6 +82,12 @@ var globalTimeout time.Duration
 var errorIsFatal bool
 var includeLicenseContents bool
 var knownLicensePath string
+var classifierName string
+var concurrency int
+var outputFormat string
+var licenseURLsConfigPath string
+var licenseRCPath string
+var outputFormatKind string
 
 func printProgress(format string, args ...interface{}) {
 	if verbose {
@@ -116,9 +132,62 @@ Flags:
 	pflag.BoolVarP(&errorIsFatal, "error-is-fatal", "e", false, "Exit fatally on any type of error, for example if the license is not found for a dependency. Default is to just store the Error in the output")
 	pflag.BoolVar(&includeLicenseContents, "include-license-contents", true, "Set to false to exclude the contents of the License file")
 	pflag.StringVarP(&knownLicensePath, "known-licenses-config", "k", "", "Path to a file containng a map of known licenses in JSON/YAML. Key should be Path@Version. This is checked first before searching for the license")
+	pflag.StringVar(&classifierName, "classifier", "licensedb", "Which license classifier backend to use. One of: licensedb, licenseclassifier")
+	pflag.IntVarP(&concurrency, "concurrency", "c", 4, "Number of dependencies to analyze concurrently")
+	pflag.StringVar(&outputFormat, "output", "stream", "Output format. One of: stream (one JSON object per dependency), array (a single JSON array)")
+	pflag.StringVar(&licenseURLsConfigPath, "license-urls-config", "", "Path to a YAML file mapping additional license URLs to their SPDX identifier, appended to the built-in list")
+	pflag.StringVar(&licenseRCPath, "license-rc-config", ".licenserc.yaml", "Path to a repo-local config file with dependency excludes, a classifier confidence threshold, and known license overrides. Ignored if it doesn't exist")
+	pflag.StringVar(&outputFormatKind, "output-format", "json", "Output document format. One of: json (see --output), spdx-json, spdx-tag-value, csv")
 
 	pflag.Parse()
 
+	licenseClassifier, err := newClassifier(classifierName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(4)
+	}
+
+	// A single Scanner is shared across every dependency so that
+	// identical LICENSE files (very common across modules released
+	// under the same license) are only classified once.
+	licenseScanner := scanner.New(licenseClassifier)
+
+	licenseURLs, err := licenseurl.Default()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load built-in license URLs: %s\n", err)
+		os.Exit(6)
+	}
+
+	if licenseURLsConfigPath != "" {
+		extra, err := licenseurl.Load(licenseURLsConfigPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load %s: %s\n", licenseURLsConfigPath, err)
+			os.Exit(6)
+		}
+		licenseURLs = licenseurl.Merge(licenseURLs, extra)
+	}
+
+	licenseRC, err := readLicenseRC(licenseRCPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load %s: %s\n", licenseRCPath, err)
+		os.Exit(7)
+	}
+
+	knownLicenses := licenseRC.Licenses
+	if len(knownLicenses) == 0 && knownLicensePath != "" {
+		printProgress("Opening config file for Known licenses:\n  %s", knownLicensePath)
+		known, err := readKnownLicenses(knownLicensePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read known license config file: %s\n", err)
+			os.Exit(8)
+		}
+		if len(known.Licenses) == 0 {
+			fmt.Fprintf(os.Stderr, "%s contained no licenses! Did you put them under \"licenses:\"?\n", knownLicensePath)
+			os.Exit(8)
+		}
+		knownLicenses = known.Licenses
+	}
+
 	var f io.Reader
 
 	if inputFile != "" {
@@ -135,9 +204,71 @@ Flags:
 		f = os.Stdin
 	}
 
-	ch := make(chan struct{}, 1)
+	deps := make(chan Dependency)
+	results := make(chan Dependency)
+
+	// A bounded pool of workers analyzes dependencies concurrently. Each
+	// dependency gets its own timeout, so one slow module only ever
+	// causes that one dependency to be emitted with License.Error set,
+	// instead of aborting the whole batch.
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for dep := range deps {
+				ctx, cancel := context.WithTimeout(context.Background(), depTimeout)
+				output, _ := GetDependencyLicense(ctx, dep,
+					WithScanner(licenseScanner),
+					WithLicenseURLs(licenseURLs),
+					WithKnownLicenses(knownLicenses),
+					WithExcludes(licenseRC.Dependency.Excludes),
+					WithThreshold(licenseRC.Dependency.Threshold),
+				)
+				cancel()
+
+				if errorIsFatal && output.License.Error != "" {
+					log.Fatalf("Fatal error for \"%s\": %s", output.Path, output.License.Error)
+				}
+
+				results <- output
+			}
+		}()
+	}
+
 	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	// A single writer goroutine serializes results, so stdout stays
+	// well-formed output even with multiple workers producing results
+	// concurrently.
+	done := make(chan struct{})
+	go func() {
+		var err error
+		switch outputFormatKind {
+		case "spdx-json":
+			err = writeSPDXJSON(results)
+		case "spdx-tag-value":
+			err = writeSPDXTagValue(results)
+		case "csv":
+			err = writeCSV(results)
+		default:
+			writeResults(results, outputFormat)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s output: %s\n", outputFormatKind, err)
+			os.Exit(3)
+		}
+		close(done)
+	}()
 
+	go func() {
 		// This reads JSON from the input, multiple objects following each other is allowed.
 		dec := json.NewDecoder(bufio.NewReader(f))
 		for {
@@ -148,26 +279,125 @@ Flags:
 			} else if err != nil {
 				log.Fatal(err)
 			}
-			GetDependencyLicense(dep)
+			deps <- dep
 		}
-
-		ch <- struct{}{}
+		close(deps)
 	}()
 
 	select {
-	case _ = <-ch:
+	case <-done:
 	case <-time.After(globalTimeout):
 		log.Fatalf("Global timeout elapsed after %s trying to get the licenses for", globalTimeout)
 	}
 }
 
+// writeResults consumes results and writes them to stdout, either as a
+// stream of one JSON object per line, or as a single JSON array once all
+// results have arrived.
+func writeResults(results <-chan Dependency, format string) {
+	switch format {
+	case "array":
+		var all []Dependency
+		for dep := range results {
+			all = append(all, dep)
+		}
+
+		bytes, err := json.Marshal(all)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal JSON: %s\n", err)
+			os.Exit(3)
+		}
+		fmt.Println(string(bytes))
+
+	default:
+		for dep := range results {
+			bytes, err := json.Marshal(dep)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to marshal JSON: %s\n", err)
+				os.Exit(3)
+			}
+			fmt.Println(string(bytes))
+		}
+	}
+}
+
+// toSPDXDependencies drains results into the spdx.Dependency shape
+// BuildDocument expects. Building an SBOM needs every result at once,
+// so unlike writeResults this cannot stream.
+func toSPDXDependencies(results <-chan Dependency) []spdx.Dependency {
+	var deps []spdx.Dependency
+	for dep := range results {
+		deps = append(deps, spdx.Dependency{
+			Path:        dep.Path,
+			Version:     dep.Version,
+			Dir:         dep.Dir,
+			LicenseName: dep.License.Name,
+			LicenseFile: dep.License.Path,
+		})
+	}
+	return deps
+}
+
+func writeSPDXJSON(results <-chan Dependency) error {
+	doc, err := spdx.BuildDocument(toSPDXDependencies(results), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
+
+func writeSPDXTagValue(results <-chan Dependency) error {
+	doc, err := spdx.BuildDocument(toSPDXDependencies(results), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	return spdx.WriteTagValue(os.Stdout, doc)
+}
+
+// writeCSV writes one row per dependency: Path, Version, License,
+// Confidence, LicensePath, Error.
+func writeCSV(results <-chan Dependency) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write([]string{"Path", "Version", "License", "Confidence", "LicensePath", "Error"}); err != nil {
+		return err
+	}
+
+	for dep := range results {
+		row := []string{
+			dep.Path,
+			dep.Version,
+			dep.License.Name,
+			fmt.Sprintf("%g", dep.License.Confidence),
+			dep.License.Path,
+			dep.License.Error,
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
 type KnownLicense struct {
 	Name string `yaml:"Name"` // Name of the License according to https://spdx.org/licenses/
 	Path string `yaml:"Path"`
+	// URL may be given instead of Path, for dependencies that are only
+	// identified by a link to a license landing page rather than a file.
+	URL string `yaml:"URL"`
 }
 
 type KnownLicenses struct {
-	Licenses map[string]KnownLicense `yaml:licenses`
+	Licenses map[string]KnownLicense `yaml:"licenses"`
 }
 
 func readKnownLicenses(path string) (*KnownLicenses, error) {
@@ -188,89 +418,311 @@ func readKnownLicenses(path string) (*KnownLicenses, error) {
 	return &known, nil
 }
 
+// ExcludeEntry identifies one or more dependencies to skip entirely,
+// via filepath.Match glob patterns on Name and, optionally, Version.
+// An empty Version matches any version of Name.
+type ExcludeEntry struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// Matches reports whether dep should be excluded per this entry.
+func (e ExcludeEntry) Matches(dep Dependency) bool {
+	if ok, _ := filepath.Match(e.Name, dep.Path); !ok {
+		return false
+	}
+	if e.Version == "" {
+		return true
+	}
+	ok, _ := filepath.Match(e.Version, dep.Version)
+	return ok
+}
+
+// DependencyConfig is the `dependency:` section of a .licenserc.yaml.
+type DependencyConfig struct {
+	Excludes []ExcludeEntry `yaml:"excludes"`
+	// Threshold is the minimum classifier confidence a match must reach
+	// to be accepted. Dependencies below it get License.Error set
+	// instead. Zero means no threshold is enforced.
+	Threshold float32 `yaml:"threshold"`
+}
+
+// LicenseRC is the repo-local config file (.licenserc.yaml by default)
+// controlling which dependencies to skip, what classifier confidence to
+// require, and known license overrides.
+type LicenseRC struct {
+	Dependency DependencyConfig        `yaml:"dependency"`
+	Licenses   map[string]KnownLicense `yaml:"licenses"`
+}
+
+// readLicenseRC reads the LicenseRC config at path. A missing file at
+// path is not an error; it is treated the same as an empty config.
+func readLicenseRC(path string) (*LicenseRC, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &LicenseRC{}, nil
+		}
+		return nil, err
+	}
+
+	var rc LicenseRC
+	if err := yaml.Unmarshal(content, &rc); err != nil {
+		return nil, err
+	}
+
+	return &rc, nil
+}
+
+// isExcluded reports whether dep matches any of excludes.
+func isExcluded(dep Dependency, excludes []ExcludeEntry) bool {
+	for _, e := range excludes {
+		if e.Matches(dep) {
+			return true
+		}
+	}
+	return false
+}
+
+// newClassifier constructs the license classifier backend selected by name.
+func newClassifier(name string) (classifier.Classifier, error) {
+	switch name {
+	case "licensedb":
+		return classifier.NewLicenseDB(), nil
+	case "licenseclassifier":
+		return classifier.NewLicenseClassifier(classifier.DefaultThreshold)
+	default:
+		return nil, fmt.Errorf("unknown classifier %q, expected one of: licensedb, licenseclassifier", name)
+	}
+}
+
+// dependencyLicenseOptions holds the options configurable via
+// GetDependencyLicenseOption.
+type dependencyLicenseOptions struct {
+	scanner       *scanner.Scanner
+	licenseURLs   licenseurl.Map
+	knownLicenses map[string]KnownLicense
+	excludes      []ExcludeEntry
+	threshold     float32
+}
+
+// GetDependencyLicenseOption configures a call to GetDependencyLicense.
+type GetDependencyLicenseOption func(*dependencyLicenseOptions)
+
+// WithScanner sets the license Scanner used to analyse a dependency's
+// directory when it has no known license entry. Passing the same
+// Scanner to every call lets license file classifications be cached
+// across dependencies.
+func WithScanner(s *scanner.Scanner) GetDependencyLicenseOption {
+	return func(o *dependencyLicenseOptions) {
+		o.scanner = s
+	}
+}
+
+// WithLicenseURLs sets the URL-to-SPDX-identifier map consulted when no
+// license file can be found in a dependency's directory.
+func WithLicenseURLs(m licenseurl.Map) GetDependencyLicenseOption {
+	return func(o *dependencyLicenseOptions) {
+		o.licenseURLs = m
+	}
+}
+
+// WithKnownLicenses sets the map of known dependency (Path or
+// Path@Version) to license, checked before any classification is done.
+func WithKnownLicenses(m map[string]KnownLicense) GetDependencyLicenseOption {
+	return func(o *dependencyLicenseOptions) {
+		o.knownLicenses = m
+	}
+}
+
+// WithExcludes sets the dependencies to skip entirely, emitting them
+// with License.Name "Excluded" instead of classifying them.
+func WithExcludes(excludes []ExcludeEntry) GetDependencyLicenseOption {
+	return func(o *dependencyLicenseOptions) {
+		o.excludes = excludes
+	}
+}
+
+// WithThreshold sets the minimum classifier confidence a match must
+// reach to be accepted. Zero means no threshold is enforced.
+func WithThreshold(threshold float32) GetDependencyLicenseOption {
+	return func(o *dependencyLicenseOptions) {
+		o.threshold = threshold
+	}
+}
+
 type AnalyzeSummary struct {
-	Result             licensedb.Result
+	Matches            []classifier.Match
+	ErrStr             string
 	LeavePathUntouched bool // Should we touch the final License path or not?
 }
 
-// GetDependencyLicense tries to figure out the license for a given dependency.
-func GetDependencyLicense(dep Dependency) {
-
-	ch := make(chan AnalyzeSummary, 1)
-	go func() {
-		// TODO: Break out into function
-		if knownLicensePath != "" {
-			log.Printf("Opening config file for Known licenses:\n  %s", knownLicensePath)
-			known, err := readKnownLicenses(knownLicensePath)
-			if err != nil {
-				log.Fatalf("Failed to read known license config file: %s\n", err)
+// knownLicenseMatch looks up dep (as Path@Version, then as Path) in
+// licenses. The second return value is false if no entry was found.
+func knownLicenseMatch(dep Dependency, licenses map[string]KnownLicense) (AnalyzeSummary, bool) {
+	for _, name := range []string{dep.Path + "@" + dep.Version, dep.Path} {
+		if knownLicense, ok := licenses[name]; ok {
+			path := knownLicense.Path
+			if path == "" {
+				// Fall back to the URL if no file Path was given.
+				path = knownLicense.URL
 			}
+			return AnalyzeSummary{
+				Matches: []classifier.Match{
+					{
+						File:       path,
+						Confidence: 1.0,
+						License:    knownLicense.Name,
+					},
+				},
+				// We provide the Path/URL for the License in the
+				// known licenses config, so we should not touch it.
+				LeavePathUntouched: true,
+			}, true
+		}
+	}
 
-			if len(known.Licenses) == 0 {
-				log.Fatalf("%s contained to licenses! Did you put them under \"licenses:\"?", knownLicensePath)
-			}
+	return AnalyzeSummary{}, false
+}
 
-			for k := range known.Licenses {
-				log.Println(k)
-			}
+// excludedSummary is the AnalyzeSummary reported for a dependency
+// matched by a .licenserc.yaml dependency.excludes entry.
+func excludedSummary() AnalyzeSummary {
+	return AnalyzeSummary{
+		Matches: []classifier.Match{
+			{License: "Excluded", Confidence: 1.0},
+		},
+		LeavePathUntouched: true,
+	}
+}
 
-			for _, name := range []string{dep.Path + "@" + dep.Version, dep.Path} {
-				log.Println("Looking for ", name)
-				if knownLicense, ok := known.Licenses[name]; ok {
-					log.Printf("  Found known license entry for %s\n", name)
-					summary := AnalyzeSummary{
-						Result: licensedb.Result{
-							Arg:    dep.Dir,
-							ErrStr: "",
-							Matches: []licensedb.Match{
-								{
-									File:       knownLicense.Path,
-									Confidence: 1.0,
-									License:    knownLicense.Name,
-								},
-							},
-						},
-						// We provide the Path for the License in the
-						// known licenses config, so we should not touch it.
-						LeavePathUntouched: true,
-					}
-
-					ch <- summary
-					return
-				}
-			}
+// analyzeDependency figures out the license for dep. In order, it
+// checks: whether dep is excluded via options.excludes, the known
+// licenses in options.knownLicenses, scanning dep.Dir with
+// options.scanner, and finally a license URL found for dep matched
+// against options.licenseURLs. It returns a non-nil error only if the
+// scan did not complete before ctx's deadline passed.
+func analyzeDependency(ctx context.Context, dep Dependency, options dependencyLicenseOptions) (AnalyzeSummary, error) {
+	if isExcluded(dep, options.excludes) {
+		return excludedSummary(), nil
+	}
+
+	if summary, ok := knownLicenseMatch(dep, options.knownLicenses); ok {
+		return summary, nil
+	}
+
+	matches, err := options.scanner.Scan(ctx, dep.Dir)
+	if err == context.DeadlineExceeded {
+		return AnalyzeSummary{}, err
+	}
+
+	if err == nil && len(matches) > 0 && options.threshold > 0 && matches[0].Confidence < options.threshold {
+		return AnalyzeSummary{
+			Matches: matches[:1],
+			ErrStr:  "confidence below threshold",
+			// The rejected match's File is still relative to dep.Dir, same
+			// as a normal scan result.
+			LeavePathUntouched: false,
+		}, nil
+	}
+
+	if len(matches) == 0 {
+		if match, ok := resolveLicenseURL(dep, options.licenseURLs); ok {
+			return AnalyzeSummary{
+				Matches: []classifier.Match{match},
+				// The match came from a license URL, not a file inside
+				// dep.Dir, so there is no path to join.
+				LeavePathUntouched: true,
+			}, nil
 		}
+	}
+
+	summary := AnalyzeSummary{
+		Matches: matches,
+		// We should have found the path to the license automatically,
+		// relative to the source directory for the dependency.
+		LeavePathUntouched: false,
+	}
+	if err != nil {
+		summary.ErrStr = err.Error()
+	}
+
+	return summary, nil
+}
+
+// urlPattern matches http(s) URLs, trimming any trailing punctuation
+// that isn't part of the URL itself (e.g. a closing paren or period).
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>()]*[^\s"'<>().,;:!?]`)
+
+// licenseSourceFiles are files, relative to a dependency's Dir, that
+// commonly reference a license by URL rather than including its text.
+var licenseSourceFiles = []string{
+	"README.md", "README", "README.rst", "README.txt",
+}
+
+// resolveLicenseURL looks for a license URL for dep, either supplied
+// directly via dep.LicenseURL or mentioned in dep.GoMod or one of
+// licenseSourceFiles, and resolves it to an SPDX identifier using urls.
+func resolveLicenseURL(dep Dependency, urls licenseurl.Map) (classifier.Match, bool) {
+	if len(urls) == 0 {
+		return classifier.Match{}, false
+	}
 
-		results := licensedb.Analyse(dep.Dir)
+	candidates := []string{dep.LicenseURL}
 
-		// Since we only pass a single directory we expect only one result
-		if len(results) != 1 {
-			log.Fatalf("Expected a single result for %s but got %d", dep.Dir, len(results))
+	if dep.GoMod != "" {
+		if content, err := ioutil.ReadFile(dep.GoMod); err == nil {
+			candidates = append(candidates, urlPattern.FindAllString(string(content), -1)...)
 		}
+	}
 
-		// Figure out what license this dependency has.
-		summary := AnalyzeSummary{
-			Result: results[0],
-			// We should have found the path to the license automatically,
-			// relative to the source directory for the dependency.
-			LeavePathUntouched: false,
+	for _, name := range licenseSourceFiles {
+		content, err := ioutil.ReadFile(filepath.Join(dep.Dir, name))
+		if err != nil {
+			continue
 		}
-		ch <- summary
-	}()
+		candidates = append(candidates, urlPattern.FindAllString(string(content), -1)...)
+	}
 
-	var summary AnalyzeSummary
+	for _, url := range candidates {
+		if spdx, ok := urls[url]; ok {
+			return classifier.Match{
+				File:       url,
+				License:    spdx,
+				Confidence: 1.0,
+			}, true
+		}
+	}
 
-	select {
-	case summary = <-ch:
-	case <-time.After(depTimeout):
-		log.Fatalf("Timed out after %v trying to get the license for: '%s'", depTimeout, dep.Path)
+	return classifier.Match{}, false
+}
+
+// GetDependencyLicense tries to figure out the license for a given
+// dependency, returning it with its License field populated. If ctx's
+// deadline passes before analysis completes, the returned Dependency has
+// License.Error set and a non-nil error is also returned so a caller
+// can decide whether to treat it as fatal (see --error-is-fatal), but
+// this never aborts analysis of any other dependency.
+func GetDependencyLicense(ctx context.Context, dep Dependency, opts ...GetDependencyLicenseOption) (Dependency, error) {
+	options := dependencyLicenseOptions{
+		scanner: scanner.New(classifier.NewLicenseDB()),
+	}
+	for _, opt := range opts {
+		opt(&options)
 	}
 
+	summary, err := analyzeDependency(ctx, dep, options)
+
 	output := dep
-	output.License.Error = summary.Result.ErrStr
+	if err != nil {
+		output.License.Error = fmt.Sprintf("Timed out after %v trying to get the license for: '%s'", depTimeout, dep.Path)
+		return output, err
+	}
 
-	if len(summary.Result.Matches) > 0 {
-		match := summary.Result.Matches[0]
+	output.License.Error = summary.ErrStr
+
+	if len(summary.Matches) > 0 {
+		match := summary.Matches[0]
 		licensePath := match.File
 		if !summary.LeavePathUntouched {
 			licensePath = filepath.Join(output.Dir, match.File)
@@ -280,9 +732,10 @@ func GetDependencyLicense(dep Dependency) {
 			Name:       match.License,
 			Path:       licensePath,
 			Confidence: match.Confidence,
+			Error:      summary.ErrStr,
 		}
 
-		if includeLicenseContents {
+		if includeLicenseContents && output.License.Path != "" && !urlPattern.MatchString(output.License.Path) {
 			b, err := ioutil.ReadFile(output.License.Path)
 			if err != nil {
 				output.License.Error = fmt.Sprintf("Failed to open license file: %s", err.Error())
@@ -291,15 +744,5 @@ func GetDependencyLicense(dep Dependency) {
 		}
 	}
 
-	bytes, err := json.Marshal(output)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to marshal JSON: %s\n", err)
-		os.Exit(3)
-	}
-
-	fmt.Println(string(bytes))
-
-	if errorIsFatal && output.License.Error != "" {
-		log.Fatalf("Fatal error for \"%s\": %s", dep.Path, output.License.Error)
-	}
+	return output, nil
 }
@@ -0,0 +1,22 @@
+// Package classifier provides pluggable backends for detecting the
+// license(s) used by a directory of source code.
+package classifier
+
+// Match represents a single license match found within a directory.
+type Match struct {
+	// File is the path to the matched license/notice file, relative to
+	// the directory that was classified.
+	File string
+	// License is the SPDX identifier (or closest approximation) of the
+	// matched license.
+	License string
+	// Confidence is how sure the backend is of the match, in the range
+	// 0.0-1.0.
+	Confidence float32
+}
+
+// Classifier scans a directory for license files and reports the
+// licenses it finds there.
+type Classifier interface {
+	Classify(dir string) ([]Match, error)
+}
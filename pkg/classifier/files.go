@@ -0,0 +1,52 @@
+package classifier
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LicenseFileNames are the file names considered as candidates for
+// containing license or notice text when walking a dependency directory.
+var LicenseFileNames = []string{
+	"LICENSE", "LICENSE.TXT", "LICENSE.MD", "LICENCE", "LICENCE.TXT",
+	"COPYING", "COPYING.TXT", "NOTICE", "NOTICE.TXT",
+}
+
+// CandidateFiles walks dir and returns the paths of files whose name
+// matches one of LicenseFileNames, relative to dir.
+func CandidateFiles(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isLicenseFile(info.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func isLicenseFile(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, candidate := range LicenseFileNames {
+		if upper == candidate {
+			return true
+		}
+	}
+	return false
+}
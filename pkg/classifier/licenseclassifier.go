@@ -0,0 +1,72 @@
+package classifier
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	lc "github.com/google/licenseclassifier/v2"
+	"github.com/google/licenseclassifier/v2/assets"
+)
+
+// DefaultThreshold is the minimum coverage a candidate file must reach
+// for LicenseClassifier to report it as a match.
+const DefaultThreshold = 0.75
+
+// LicenseClassifier is a Classifier backed by
+// github.com/google/licenseclassifier/v2 and its embedded license
+// corpus. Building the underlying classifier is expensive, so a single
+// instance is shared across all calls to Classify.
+type LicenseClassifier struct {
+	threshold float64
+	lc        *lc.Classifier
+}
+
+// NewLicenseClassifier creates a LicenseClassifier using the embedded
+// license corpus shipped with google/licenseclassifier, requiring a
+// minimum coverage of threshold (0.0-1.0) for a file to be reported as
+// a match.
+func NewLicenseClassifier(threshold float64) (*LicenseClassifier, error) {
+	c, err := assets.DefaultClassifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded license assets: %w", err)
+	}
+
+	return &LicenseClassifier{
+		threshold: threshold,
+		lc:        c,
+	}, nil
+}
+
+// Classify implements Classifier. It walks dir looking for candidate
+// license/notice files and feeds each one into the shared
+// classifier.Classifier instance.
+func (c *LicenseClassifier) Classify(dir string) ([]Match, error) {
+	files, err := CandidateFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+
+	for _, rel := range files {
+		content, err := ioutil.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range c.lc.Match(content).Matches {
+			if result.Confidence < c.threshold {
+				continue
+			}
+
+			matches = append(matches, Match{
+				File:       rel,
+				License:    result.Name,
+				Confidence: float32(result.Confidence),
+			})
+		}
+	}
+
+	return matches, nil
+}
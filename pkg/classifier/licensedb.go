@@ -0,0 +1,44 @@
+package classifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-enry/go-license-detector/v4/licensedb"
+)
+
+// LicenseDB is a Classifier backed by github.com/go-enry/go-license-detector.
+// It is the classifier go-license-finder has always used.
+type LicenseDB struct{}
+
+// NewLicenseDB returns a Classifier that uses go-license-detector to
+// analyse a directory.
+func NewLicenseDB() *LicenseDB {
+	return &LicenseDB{}
+}
+
+// Classify implements Classifier.
+func (c *LicenseDB) Classify(dir string) ([]Match, error) {
+	results := licensedb.Analyse(dir)
+
+	// Since we only pass a single directory we expect only one result.
+	if len(results) != 1 {
+		return nil, fmt.Errorf("expected a single result for %s but got %d", dir, len(results))
+	}
+
+	result := results[0]
+	if result.ErrStr != "" {
+		return nil, errors.New(result.ErrStr)
+	}
+
+	matches := make([]Match, 0, len(result.Matches))
+	for _, m := range result.Matches {
+		matches = append(matches, Match{
+			File:       m.File,
+			License:    m.License,
+			Confidence: m.Confidence,
+		})
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,61 @@
+// Package licenseurl resolves a license URL (as found in a go.mod,
+// README, or supplied directly) to its SPDX identifier.
+package licenseurl
+
+import (
+	"embed"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed assets/urls.yaml
+var defaultURLs embed.FS
+
+// Map maps a license URL to its SPDX identifier.
+type Map map[string]string
+
+// Default returns the built-in map of well-known license landing page
+// URLs to SPDX identifiers.
+func Default() (Map, error) {
+	content, err := defaultURLs.ReadFile("assets/urls.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(content)
+}
+
+// Load reads a user-supplied YAML file at path, in the same
+// URL-to-SPDX-identifier format as the built-in map.
+func Load(path string) (Map, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(content)
+}
+
+// Merge returns a new Map containing every entry of base, with entries
+// from override taking precedence over base on conflicting URLs.
+func Merge(base, override Map) Map {
+	merged := make(Map, len(base)+len(override))
+	for url, spdx := range base {
+		merged[url] = spdx
+	}
+	for url, spdx := range override {
+		merged[url] = spdx
+	}
+
+	return merged
+}
+
+func parse(content []byte) (Map, error) {
+	var m Map
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
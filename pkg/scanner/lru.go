@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"container/list"
+
+	"github.com/JoakimSoderberg/go-license-finder/pkg/classifier"
+)
+
+// lruCache is a fixed-size least-recently-used cache of classifier
+// matches, keyed by content hash. It is not safe for concurrent use;
+// callers are expected to guard it with their own lock (see Scanner).
+type lruCache struct {
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []classifier.Match
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key string) ([]classifier.Match, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) add(key string, value []classifier.Match) {
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*lruEntry).value = value
+		return
+	}
+
+	elem := c.ll.PushFront(&lruEntry{key: key, value: value})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		c.removeOldest()
+	}
+}
+
+func (c *lruCache) removeOldest() {
+	elem := c.ll.Back()
+	if elem == nil {
+		return
+	}
+
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry).key)
+}
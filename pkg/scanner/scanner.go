@@ -0,0 +1,123 @@
+// Package scanner provides a long-lived Scanner that runs a single
+// classifier.Classifier over many dependencies, caching results so
+// identical license files (very common across modules under the same
+// license) are only classified once.
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/JoakimSoderberg/go-license-finder/pkg/classifier"
+)
+
+// DefaultCacheSize is the number of distinct license file contents kept
+// in the Scanner's cache.
+const DefaultCacheSize = 256
+
+// Scanner classifies dependency directories using a single shared
+// classifier.Classifier instance, caching results by the SHA256 of the
+// candidate license file contents found in a directory.
+type Scanner struct {
+	classifier classifier.Classifier
+
+	mu    sync.Mutex
+	cache *lruCache
+}
+
+// New creates a Scanner that classifies with c and caches up to
+// DefaultCacheSize distinct results.
+func New(c classifier.Classifier) *Scanner {
+	return &Scanner{
+		classifier: c,
+		cache:      newLRUCache(DefaultCacheSize),
+	}
+}
+
+// Scan classifies dir, returning early with ctx.Err() if ctx is done
+// before the classifier finishes. Results are cached by the hash of the
+// candidate license file contents in dir, so scanning the same file
+// contents again is served from cache without invoking the classifier.
+func (s *Scanner) Scan(ctx context.Context, dir string) ([]classifier.Match, error) {
+	hash, err := hashLicenseFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash != "" {
+		if matches, ok := s.lookup(hash); ok {
+			return matches, nil
+		}
+	}
+
+	type result struct {
+		matches []classifier.Match
+		err     error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		matches, err := s.classifier.Classify(dir)
+		ch <- result{matches, err}
+	}()
+
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			return nil, res.err
+		}
+		if hash != "" {
+			s.store(hash, res.matches)
+		}
+		return res.matches, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scanner) lookup(hash string) ([]classifier.Match, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.get(hash)
+}
+
+func (s *Scanner) store(hash string, matches []classifier.Match) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.add(hash, matches)
+}
+
+// hashLicenseFiles returns the hex-encoded SHA256 of the candidate license
+// files in dir, in the order reported by classifier.CandidateFiles. Each
+// file's dir-relative name is folded into the hash alongside its contents,
+// so two directories whose license text is byte-identical but laid out
+// under different filenames (e.g. LICENSE vs COPYING) do not collide on the
+// same cache key, since a cached Match.File would resolve incorrectly
+// against the other directory. It returns "" if dir has no candidate
+// files, in which case the result should not be cached.
+func hashLicenseFiles(dir string) (string, error) {
+	files, err := classifier.CandidateFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	h := sha256.New()
+	for _, rel := range files {
+		content, err := ioutil.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(rel))
+		h.Write([]byte{0})
+		h.Write(content)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
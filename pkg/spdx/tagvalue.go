@@ -0,0 +1,51 @@
+package spdx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTagValue writes doc to w in the SPDX tag-value format.
+func WriteTagValue(w io.Writer, doc *Document) error {
+	lines := []string{
+		"SPDXVersion: " + doc.SPDXVersion,
+		"DataLicense: " + doc.DataLicense,
+		"SPDXID: " + doc.SPDXID,
+		"DocumentName: " + doc.Name,
+		"DocumentNamespace: " + doc.DocumentNamespace,
+	}
+	for _, creator := range doc.CreationInfo.Creators {
+		lines = append(lines, "Creator: "+creator)
+	}
+	lines = append(lines, "Created: "+doc.CreationInfo.Created)
+
+	for _, pkg := range doc.Packages {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("##### Package: %s", pkg.Name),
+			"",
+			"PackageName: "+pkg.Name,
+			"SPDXID: "+pkg.SPDXID,
+			"PackageVersion: "+pkg.VersionInfo,
+			"PackageDownloadLocation: "+pkg.DownloadLocation,
+			fmt.Sprintf("FilesAnalyzed: %t", pkg.FilesAnalyzed),
+			"PackageLicenseConcluded: "+pkg.LicenseConcluded,
+			"PackageLicenseDeclared: "+pkg.LicenseDeclared,
+			"PackageCopyrightText: "+pkg.CopyrightText,
+		)
+
+		for _, file := range pkg.LicenseInfoInFiles {
+			lines = append(lines, "LicenseInfoInFile: "+file)
+		}
+		for _, ref := range pkg.ExternalRefs {
+			lines = append(lines, fmt.Sprintf("ExternalRef: %s %s %s", ref.ReferenceCategory, ref.ReferenceType, ref.ReferenceLocator))
+		}
+		for _, sum := range pkg.Checksums {
+			lines = append(lines, fmt.Sprintf("PackageChecksum: %s: %s", sum.Algorithm, sum.ChecksumValue))
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}